@@ -0,0 +1,105 @@
+// Command bootstrap provisions the default roles and an initial admin user
+// against a fresh database. It is safe to re-run: existing roles and an
+// existing admin account are left untouched, so it can be wired into a
+// container init step without risking duplicate writes.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/config"
+	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/db"
+	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/user"
+)
+
+func main() {
+	ctx := context.Background()
+
+	cfg := config.LoadDatabaseConfig()
+	bunDB, err := db.OpenAndMigrate(ctx, cfg)
+	if err != nil {
+		log.Fatalf("bootstrap: connecting to database: %v", err)
+	}
+	defer bunDB.Close()
+
+	if err := run(ctx, user.NewRepository(bunDB)); err != nil {
+		log.Fatalf("bootstrap: %v", err)
+	}
+}
+
+// run ensures the default roles exist and, unless a user with
+// BOOTSTRAP_ADMIN_EMAIL already exists, creates an admin from
+// BOOTSTRAP_ADMIN_EMAIL / BOOTSTRAP_ADMIN_PASSWORD. If
+// BOOTSTRAP_ADMIN_PASSWORD is unset, a random password is generated and
+// printed once.
+func run(ctx context.Context, repo user.Repository) error {
+	if err := repo.EnsureDefaultRoles(ctx); err != nil {
+		return fmt.Errorf("ensuring default roles: %w", err)
+	}
+
+	email := os.Getenv("BOOTSTRAP_ADMIN_EMAIL")
+	if email == "" {
+		log.Println("BOOTSTRAP_ADMIN_EMAIL not set, skipping admin user creation")
+		return nil
+	}
+
+	existing, err := repo.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("checking for existing admin user: %w", err)
+	}
+	if existing != nil {
+		log.Printf("user %s already exists, skipping admin user creation", email)
+		return nil
+	}
+
+	password := os.Getenv("BOOTSTRAP_ADMIN_PASSWORD")
+	generated := password == ""
+	if generated {
+		if password, err = randomPassword(); err != nil {
+			return fmt.Errorf("generating admin password: %w", err)
+		}
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("hashing admin password: %w", err)
+	}
+
+	admin := &user.User{
+		Name:         "Administrator",
+		Email:        email,
+		PasswordHash: string(hash),
+	}
+
+	err = repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := repo.Create(ctx, admin); err != nil {
+			return err
+		}
+		return repo.AssignRole(ctx, uint(admin.ID), user.RoleAdmin)
+	})
+	if err != nil {
+		return fmt.Errorf("creating admin user: %w", err)
+	}
+
+	log.Printf("created admin user %s", email)
+	if generated {
+		log.Printf("generated admin password (shown once, store it now): %s", password)
+	}
+	return nil
+}
+
+// randomPassword returns a URL-safe, base64-encoded random password.
+func randomPassword() (string, error) {
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,30 @@
+package config
+
+import "github.com/spf13/viper"
+
+// DatabaseConfig holds the configuration needed to connect to the
+// configured database backend.
+type DatabaseConfig struct {
+	Driver   string
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Name     string
+	SSLMode  string
+}
+
+// LoadDatabaseConfig loads database configuration using Viper (env overrides + defaults).
+func LoadDatabaseConfig() DatabaseConfig {
+	viper.SetDefault("database.driver", "postgres")
+
+	return DatabaseConfig{
+		Driver:   viper.GetString("database.driver"),
+		Host:     viper.GetString("database.host"),
+		Port:     viper.GetInt("database.port"),
+		User:     viper.GetString("database.user"),
+		Password: viper.GetString("database.password"),
+		Name:     viper.GetString("database.name"),
+		SSLMode:  viper.GetString("database.sslmode"),
+	}
+}
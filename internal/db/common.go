@@ -0,0 +1,19 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// newBunDB wraps sqldb with bun, tunes its pool via LoadPoolConfig, and
+// installs MetricsQueryHook. Every Backend.Open should build its *bun.DB
+// through this so pooling and observability stay consistent across drivers.
+func newBunDB(sqldb *sql.DB, dialect schema.Dialect) *bun.DB {
+	LoadPoolConfig().Apply(sqldb)
+
+	bunDB := bun.NewDB(sqldb, dialect)
+	bunDB.AddQueryHook(MetricsQueryHook{})
+	return bunDB
+}
@@ -2,108 +2,73 @@ package db
 
 import (
 	"context"
-	"errors"
+	"embed"
 	"fmt"
-	"log"
-	"time"
 
-	"github.com/spf13/viper"
 	"github.com/uptrace/bun"
-	"github.com/uptrace/bun/dialect/pgdialect"
-	"github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/config"
 )
 
-// customLogger wraps the default logger to ignore ErrRecordNotFound
-type customLogger struct {
-	logger.Interface
+// Backend connects to a concrete database driver and returns a *bun.DB
+// configured with the matching dialect. Each supported driver (Postgres,
+// MySQL, SQLite) implements this so the rest of the application can stay
+// driver-agnostic.
+type Backend interface {
+	// Name returns the driver name as used in the `database.driver` config
+	// value (e.g. "postgres", "mysql", "sqlite").
+	Name() string
+	// Open connects to the database and returns a *bun.DB ready to use.
+	Open(ctx context.Context, cfg config.DatabaseConfig) (*bun.DB, error)
+	// Migrations returns the embedded `.sql` migration files for this
+	// driver. The FS is rooted at the db package directory and holds only
+	// that driver's files, under migrations/{driver}/.
+	Migrations() embed.FS
 }
 
-func (l customLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
-	// Don't log "record not found" errors as they are expected in many cases
-	if err != nil && errors.Is(err, sql.ErrNoRows) {
-		return
-	}
-	l.Interface.Trace(ctx, begin, fc, err)
+var backends = map[string]Backend{
+	"postgres": pgBackend{},
+	"mysql":    mysqlBackend{},
+	"sqlite":   sqliteBackend{},
 }
 
-func (l customLogger) Error(ctx context.Context, msg string, data ...interface{}) {
-	// Don't log "record not found" errors as they are expected in many cases
-	if len(data) > 0 {
-		if err, ok := data[0].(error); ok && errors.Is(err, gorm.ErrRecordNotFound) {
-			return
-		}
+// Open selects a Backend based on cfg.Driver and opens a connection to it.
+func Open(ctx context.Context, cfg config.DatabaseConfig) (*bun.DB, error) {
+	backend, err := backendFor(cfg.Driver)
+	if err != nil {
+		return nil, err
 	}
-	l.Interface.Error(ctx, msg, data...)
-}
-
-// Config holds database configuration
-type Config struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
-}
-
-// NewPostgresDB creates a new PostgreSQL database connection
-func NewPostgresDB(cfg Config) (*bun.DB, error) {
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode)
-		config, err := pgx.ParseConfig(dsn)
-	pool, err := pgxpool.NewWithConfig(context.Background(), config)
-if err != nil {
-	return nil, fmt.Errorf("failed to connect to database: %w", err)
-}
-
-sqldb := stdlib.OpenDBFromPool(pool)
-db := bun.NewDB(sqldb, pgdialect.New())
-	log.Println("Database connection established")
-	return db, nil
+	return backend.Open(ctx, cfg)
 }
 
-// NewPostgresDBFromDatabaseConfig creates a new PostgreSQL DB connection from typed config
-func NewPostgresDBFromDatabaseConfig(cfg config.DatabaseConfig) (*bun.DB, error) {
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode)
-		config, err := pgx.ParseConfig(dsn)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: customLogger{logger.Default.LogMode(logger.Info)},
-	})
+// OpenAndMigrate opens a connection for cfg.Driver and applies any pending
+// migrations for that driver before returning the DB.
+func OpenAndMigrate(ctx context.Context, cfg config.DatabaseConfig) (*bun.DB, error) {
+	backend, err := backendFor(cfg.Driver)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to postgres database: %w", err)
+		return nil, err
 	}
 
-	config.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
-
-sqldb := stdlib.OpenDB(*config)
-db := bun.NewDB(sqldb, pgdialect.New())
-
-	return db, nil
-}
-
-// NewSQLiteDB creates a new SQLite database connection (for testing)
-func NewSQLiteDB(dbPath string) (*bun.DB, error) {
+	bunDB, err := backend.Open(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
 
-sqldb, err := sql.Open(sqliteshim.ShimName, "file:test.db?cache=shared&mode=rwc")
-if err != nil {
-		return nil, fmt.Errorf("failed to connect to sqlite database: %w", err)
+	migrator, err := NewMigrator(bunDB, backend)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrator.Migrate(ctx); err != nil {
+		return nil, fmt.Errorf("db: applying migrations: %w", err)
 	}
-db := bun.NewDB(sqldb, sqlitedialect.New())
 
-	return db, nil
+	return bunDB, nil
 }
 
-// LoadConfigFromEnv loads database configuration using Viper (env overrides + defaults)
-func LoadConfigFromEnv() Config {
-	return Config{
-		Host:     viper.GetString("database.host"),
-		Port:     viper.GetInt("database.port"),
-		User:     viper.GetString("database.user"),
-		Password: viper.GetString("database.password"),
-		Name:     viper.GetString("database.name"),
-		SSLMode:  viper.GetString("database.sslmode"),
+func backendFor(driver string) (Backend, error) {
+	backend, ok := backends[driver]
+	if !ok {
+		return nil, fmt.Errorf("db: unsupported database driver %q", driver)
 	}
+	return backend, nil
 }
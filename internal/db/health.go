@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// poolStats mirrors the subset of sql.DBStats useful for diagnosing slow
+// queries and pool exhaustion.
+type poolStats struct {
+	InUse     int   `json:"in_use"`
+	Idle      int   `json:"idle"`
+	WaitCount int64 `json:"wait_count"`
+}
+
+type healthResponse struct {
+	OK    bool      `json:"ok"`
+	Error string    `json:"error,omitempty"`
+	Pool  poolStats `json:"pool"`
+}
+
+// HealthHandler returns a handler for /healthz/db: it pings bunDB with the
+// given timeout and reports its connection pool stats alongside the result.
+func HealthHandler(bunDB *bun.DB, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		stats := bunDB.Stats()
+		resp := healthResponse{
+			Pool: poolStats{
+				InUse:     stats.InUse,
+				Idle:      stats.Idle,
+				WaitCount: stats.WaitCount,
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := bunDB.PingContext(ctx); err != nil {
+			resp.Error = err.Error()
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			resp.OK = true
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+// Migrator applies a Backend's `.sql` migrations on startup using
+// bun/migrate. It discovers migrations from the driver-specific directory
+// under internal/db/migrations/{driver}/ so each backend only ever sees
+// SQL it can actually run.
+type Migrator struct {
+	db       *bun.DB
+	migrator *migrate.Migrator
+}
+
+// NewMigrator discovers backend's embedded migrations and prepares a
+// Migrator that can apply them against db.
+func NewMigrator(db *bun.DB, backend Backend) (*Migrator, error) {
+	migrations := migrate.NewMigrations()
+	if err := migrations.Discover(backend.Migrations()); err != nil {
+		return nil, fmt.Errorf("db: discovering %s migrations: %w", backend.Name(), err)
+	}
+
+	return &Migrator{
+		db:       db,
+		migrator: migrate.NewMigrator(db, migrations),
+	}, nil
+}
+
+// Migrate applies all pending migrations, creating the migrations bookkeeping
+// tables on first run.
+func (m *Migrator) Migrate(ctx context.Context) error {
+	if err := m.migrator.Init(ctx); err != nil {
+		return err
+	}
+
+	if _, err := m.migrator.Lock(ctx); err != nil {
+		return fmt.Errorf("db: locking migrations: %w", err)
+	}
+	defer m.migrator.Unlock(ctx) //nolint:errcheck
+
+	_, err := m.migrator.Migrate(ctx)
+	return err
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"log"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/mysqldialect"
+
+	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/config"
+)
+
+//go:embed migrations/mysql/*.sql
+var mysqlMigrations embed.FS
+
+// mysqlBackend opens a *bun.DB backed by MySQL via go-sql-driver/mysql.
+type mysqlBackend struct{}
+
+func (mysqlBackend) Name() string { return "mysql" }
+
+func (mysqlBackend) Open(ctx context.Context, cfg config.DatabaseConfig) (*bun.DB, error) {
+	mysqlCfg := mysql.NewConfig()
+	mysqlCfg.Net = "tcp"
+	mysqlCfg.Addr = fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	mysqlCfg.User = cfg.User
+	mysqlCfg.Passwd = cfg.Password
+	mysqlCfg.DBName = cfg.Name
+	mysqlCfg.ParseTime = true
+	// Migration files hold several statements each and are sent in one Exec.
+	mysqlCfg.MultiStatements = true
+
+	sqldb, err := sql.Open("mysql", mysqlCfg.FormatDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mysql database: %w", err)
+	}
+	bunDB := newBunDB(sqldb, mysqldialect.New())
+
+	if err := bunDB.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to mysql database: %w", err)
+	}
+
+	log.Println("Database connection established (mysql)")
+	return bunDB, nil
+}
+
+func (mysqlBackend) Migrations() embed.FS { return mysqlMigrations }
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var queryDuration = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of database queries in seconds, labeled by operation and table.",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"operation", "table"},
+)
+
+var tracer = otel.Tracer("internal/db")
+
+type querySpanKey struct{}
+
+// MetricsQueryHook is a bun.QueryHook that records db_query_duration_seconds
+// histograms and an OpenTelemetry span for every query bun runs. Install it
+// with bunDB.AddQueryHook(MetricsQueryHook{}).
+type MetricsQueryHook struct{}
+
+// BeforeQuery starts the span that AfterQuery closes out.
+func (MetricsQueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	spanCtx, span := tracer.Start(ctx, event.Operation())
+	return context.WithValue(spanCtx, querySpanKey{}, span)
+}
+
+// AfterQuery records the query's duration and closes its span.
+func (MetricsQueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	duration := time.Since(event.StartTime)
+
+	table := ""
+	if tableModel, ok := event.Model.(bun.TableModel); ok {
+		table = tableModel.Table().Name
+	}
+
+	queryDuration.WithLabelValues(event.Operation(), table).Observe(duration.Seconds())
+
+	span, ok := ctx.Value(querySpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(
+		attribute.String("db.table", table),
+		attribute.String("db.statement", event.Query),
+	)
+	if event.Err != nil {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	}
+	span.End()
+}
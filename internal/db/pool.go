@@ -0,0 +1,40 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// PoolConfig tunes the underlying *sql.DB connection pool shared by every
+// Backend.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// LoadPoolConfig loads pool tuning using Viper (env overrides + defaults).
+func LoadPoolConfig() PoolConfig {
+	viper.SetDefault("database.pool.max_open_conns", 25)
+	viper.SetDefault("database.pool.max_idle_conns", 25)
+	viper.SetDefault("database.pool.conn_max_lifetime", 5*time.Minute)
+	viper.SetDefault("database.pool.conn_max_idle_time", 5*time.Minute)
+
+	return PoolConfig{
+		MaxOpenConns:    viper.GetInt("database.pool.max_open_conns"),
+		MaxIdleConns:    viper.GetInt("database.pool.max_idle_conns"),
+		ConnMaxLifetime: viper.GetDuration("database.pool.conn_max_lifetime"),
+		ConnMaxIdleTime: viper.GetDuration("database.pool.conn_max_idle_time"),
+	}
+}
+
+// Apply tunes sqldb's pool according to cfg.
+func (cfg PoolConfig) Apply(sqldb *sql.DB) {
+	sqldb.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqldb.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqldb.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqldb.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+
+	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/config"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// pgBackend opens a *bun.DB backed by PostgreSQL via pgx/stdlib.
+type pgBackend struct{}
+
+func (pgBackend) Name() string { return "postgres" }
+
+func (pgBackend) Open(ctx context.Context, cfg config.DatabaseConfig) (*bun.DB, error) {
+	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode)
+
+	pgxCfg, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse postgres dsn: %w", err)
+	}
+
+	sqldb := stdlib.OpenDB(*pgxCfg)
+	bunDB := newBunDB(sqldb, pgdialect.New())
+
+	if err := bunDB.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to postgres database: %w", err)
+	}
+
+	log.Println("Database connection established (postgres)")
+	return bunDB, nil
+}
+
+func (pgBackend) Migrations() embed.FS { return postgresMigrations }
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"log"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	_ "github.com/uptrace/bun/driver/sqliteshim"
+
+	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/config"
+)
+
+//go:embed migrations/sqlite/*.sql
+var sqliteMigrations embed.FS
+
+// sqliteBackend opens a *bun.DB backed by SQLite. cfg.Name is used as the
+// file path (or DSN) to open; an empty Name falls back to a shared
+// in-memory database.
+type sqliteBackend struct{}
+
+func (sqliteBackend) Name() string { return "sqlite" }
+
+func (sqliteBackend) Open(ctx context.Context, cfg config.DatabaseConfig) (*bun.DB, error) {
+	dsn := cfg.Name
+	if dsn == "" {
+		dsn = "file::memory:?cache=shared"
+	}
+
+	sqldb, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to sqlite database: %w", err)
+	}
+	bunDB := newBunDB(sqldb, sqlitedialect.New())
+
+	if err := bunDB.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to sqlite database: %w", err)
+	}
+
+	log.Println("Database connection established (sqlite)")
+	return bunDB, nil
+}
+
+func (sqliteBackend) Migrations() embed.FS { return sqliteMigrations }
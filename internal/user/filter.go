@@ -0,0 +1,29 @@
+package user
+
+// SearchMode selects how UserFilterParams.Search is matched against users.
+type SearchMode string
+
+const (
+	// SearchModeLike matches via a LIKE pattern on name/email. Works on
+	// every supported driver; the default when Search is set.
+	SearchModeLike SearchMode = "like"
+	// SearchModeFTS matches via Postgres full-text search over name/email,
+	// ranked by ts_rank. Only supported on Postgres; repository falls back
+	// to SearchModeLike on other drivers.
+	SearchModeFTS SearchMode = "fts"
+)
+
+// UserFilterParams narrows and orders the results of ListAllUsers.
+type UserFilterParams struct {
+	Role   string
+	Search string
+	Sort   string
+	Order  string
+
+	// SearchMode picks how Search is matched. Defaults to SearchModeLike
+	// when empty.
+	SearchMode SearchMode
+	// Highlight requests ts_headline snippets alongside SearchModeFTS
+	// results. Populated on User.Highlight; ignored otherwise.
+	Highlight bool
+}
@@ -0,0 +1,56 @@
+package user
+
+import (
+	"context"
+	"net/http"
+)
+
+type userIDKey struct{}
+
+// WithUserID attaches an authenticated user's ID to ctx. Upstream auth
+// middleware is expected to call this once the request is authenticated;
+// RequirePermission reads it back via UserIDFromContext.
+func WithUserID(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the user ID set by WithUserID, if any.
+func UserIDFromContext(ctx context.Context) (uint, bool) {
+	id, ok := ctx.Value(userIDKey{}).(uint)
+	return id, ok
+}
+
+// RequirePermission returns middleware that grants the request only if the
+// authenticated user holds action, replacing ad-hoc User.IsAdmin() checks at
+// individual handlers. When resourceIDParam is non-empty, its value is read
+// from the request's path value and passed to PolicyEngine.Can so
+// `:self`-scoped permissions (e.g. editing one's own profile) can be
+// evaluated against it.
+func RequirePermission(engine *PolicyEngine, action, resource, resourceIDParam string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			userID, ok := UserIDFromContext(req.Context())
+			if !ok {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			resourceID := ""
+			if resourceIDParam != "" {
+				resourceID = req.PathValue(resourceIDParam)
+			}
+
+			allowed, err := engine.Can(req.Context(), userID, action, resource, resourceID)
+			if err != nil {
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+			if !allowed {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
@@ -3,20 +3,24 @@ package user
 import (
 	"time"
 
-	"gorm.io/gorm"
+	"github.com/uptrace/bun"
 )
 
 // User represents a user in the system
 type User struct {
 	bun.BaseModel `bun:"table:users,alias:u"`
-	ID           int64           `bun:"id,pk,autoincrement" json:"id"`
-	Name         string         `bun:"name,notnull" json:"name"`
-	Email        string         `bun:"email,uniqueIndex,notnull" json:"email"`
-	PasswordHash string         `bun:"password_hash,notnull" json:"-"`
-	Roles        []Role         `bun:"m2m:user_roles,join:User=Role" json:"-"`
-	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
-    UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
-    DeletedAt bun.NullTime `bun:"deleted_at,soft_delete" json:"-"`
+	ID           int64        `bun:"id,pk,autoincrement" json:"id"`
+	Name         string       `bun:"name,notnull" json:"name"`
+	Email        string       `bun:"email,uniqueIndex,notnull" json:"email"`
+	PasswordHash string       `bun:"password_hash,notnull" json:"-"`
+	Roles        []Role       `bun:"m2m:user_roles,join:User=Role" json:"-"`
+	CreatedAt    time.Time    `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt    time.Time    `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+	DeletedAt    bun.NullTime `bun:"deleted_at,soft_delete" json:"-"`
+
+	// Highlight carries a ts_headline snippet when the result came from a
+	// SearchModeFTS query with Highlight requested. It is never persisted.
+	Highlight string `bun:"-" json:"highlight,omitempty"`
 }
 
 // HasRole checks if user has specific role
@@ -0,0 +1,40 @@
+package user
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Common permissions seeded for the default roles. Actions follow a
+// `resource:verb[:scope]` convention; a `:self` scope means the action is
+// only allowed against a resource the acting user owns, enforced via
+// ResourceOwnerFunc in the PolicyEngine.
+const (
+	PermUsersRead       = "users:read"
+	PermUsersWrite      = "users:write"
+	PermUsersWriteSelf  = "users:write:self"
+	PermUsersDelete     = "users:delete"
+	PermRolesAssign     = "roles:assign"
+	PermRolesManage     = "roles:manage"
+	PermPermissionsView = "permissions:view"
+)
+
+// Permission represents a single grantable action in the system.
+type Permission struct {
+	bun.BaseModel `bun:"table:permissions,alias:p"`
+
+	ID          int64     `bun:"id,pk,autoincrement" json:"id"`
+	Name        string    `bun:"name,unique,notnull" json:"name"`
+	Description string    `bun:"description" json:"description"`
+	CreatedAt   time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// RolePermission is the join row granting a Permission to a Role.
+type RolePermission struct {
+	bun.BaseModel `bun:"table:role_permissions,alias:rp"`
+
+	RoleID       int64     `bun:"role_id,pk" json:"role_id"`
+	PermissionID int64     `bun:"permission_id,pk" json:"permission_id"`
+	GrantedAt    time.Time `bun:"granted_at,nullzero,notnull,default:current_timestamp" json:"granted_at"`
+}
@@ -0,0 +1,66 @@
+package user
+
+import "context"
+
+// ResourceOwnerFunc reports the user ID that owns resourceID, so the
+// PolicyEngine can grant `:self`-scoped permissions to the resource's owner
+// even without a blanket grant. It should return an error only if ownership
+// could not be determined (e.g. the resource doesn't exist).
+type ResourceOwnerFunc func(ctx context.Context, resourceID string) (ownerID uint, err error)
+
+// PolicyEngine resolves (user, action, resource) authorization decisions
+// against the RBAC tables, including `:self`-scoped actions via a registered
+// ResourceOwnerFunc.
+type PolicyEngine struct {
+	repo   Repository
+	owners map[string]ResourceOwnerFunc
+}
+
+// NewPolicyEngine creates a PolicyEngine backed by repo.
+func NewPolicyEngine(repo Repository) *PolicyEngine {
+	return &PolicyEngine{repo: repo, owners: make(map[string]ResourceOwnerFunc)}
+}
+
+// RegisterResourceOwner associates a resource kind (e.g. "users") with the
+// function used to resolve its owner, so `<resource>:<verb>:self`
+// permissions can be evaluated for that kind.
+func (p *PolicyEngine) RegisterResourceOwner(resource string, fn ResourceOwnerFunc) {
+	p.owners[resource] = fn
+}
+
+// Can reports whether userID may perform action, optionally scoped to
+// resourceID (e.g. a user ID being edited). action is a permission name
+// such as "users:write"; Can also grants the decision if the user holds the
+// corresponding "<resource>:<verb>:self" permission and owns resourceID.
+func (p *PolicyEngine) Can(ctx context.Context, userID uint, action, resource, resourceID string) (bool, error) {
+	permissions, err := p.repo.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	granted := make(map[string]bool, len(permissions))
+	for _, perm := range permissions {
+		granted[perm.Name] = true
+	}
+
+	if granted[action] {
+		return true, nil
+	}
+
+	selfAction := action + ":self"
+	if resourceID != "" && granted[selfAction] {
+		ownerFn, ok := p.owners[resource]
+		if !ok {
+			return false, nil
+		}
+		ownerID, err := ownerFn(ctx, resourceID)
+		if err != nil {
+			return false, err
+		}
+		if ownerID == userID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
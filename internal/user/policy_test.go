@@ -0,0 +1,118 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stubRepository implements Repository with only GetUserPermissions wired up;
+// PolicyEngine.Can is the only method under test here and it never calls the
+// rest of the interface.
+type stubRepository struct {
+	Repository
+	permissions []Permission
+	err         error
+}
+
+func (s *stubRepository) GetUserPermissions(ctx context.Context, userID uint) ([]Permission, error) {
+	return s.permissions, s.err
+}
+
+func TestPolicyEngineCan(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []Permission
+		owners      map[string]ResourceOwnerFunc
+		userID      uint
+		action      string
+		resource    string
+		resourceID  string
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name:        "action directly granted",
+			permissions: []Permission{{Name: PermUsersRead}},
+			userID:      1,
+			action:      PermUsersRead,
+			resource:    "users",
+			resourceID:  "",
+			want:        true,
+		},
+		{
+			name:        "self scope allows owner",
+			permissions: []Permission{{Name: PermUsersWriteSelf}},
+			owners: map[string]ResourceOwnerFunc{
+				"users": func(ctx context.Context, resourceID string) (uint, error) { return 1, nil },
+			},
+			userID:     1,
+			action:     PermUsersWrite,
+			resource:   "users",
+			resourceID: "1",
+			want:       true,
+		},
+		{
+			name:        "self scope denies non-owner",
+			permissions: []Permission{{Name: PermUsersWriteSelf}},
+			owners: map[string]ResourceOwnerFunc{
+				"users": func(ctx context.Context, resourceID string) (uint, error) { return 2, nil },
+			},
+			userID:     1,
+			action:     PermUsersWrite,
+			resource:   "users",
+			resourceID: "1",
+			want:       false,
+		},
+		{
+			name:        "unregistered resource owner denies self scope",
+			permissions: []Permission{{Name: PermUsersWriteSelf}},
+			userID:      1,
+			action:      PermUsersWrite,
+			resource:    "users",
+			resourceID:  "1",
+			want:        false,
+		},
+		{
+			name:        "no matching permission denies",
+			permissions: []Permission{{Name: PermUsersRead}},
+			userID:      1,
+			action:      PermUsersDelete,
+			resource:    "users",
+			resourceID:  "",
+			want:        false,
+		},
+		{
+			name:    "repository error propagates",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepository{permissions: tt.permissions}
+			if tt.wantErr {
+				repo.err = errors.New("boom")
+			}
+
+			engine := NewPolicyEngine(repo)
+			for resource, fn := range tt.owners {
+				engine.RegisterResourceOwner(resource, fn)
+			}
+
+			got, err := engine.Can(context.Background(), tt.userID, tt.action, tt.resource, tt.resourceID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Can() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Can() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Can() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,12 +2,18 @@ package user
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 	"time"
 
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
+	"github.com/uptrace/bun"
+
+	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/user/dbgen"
+	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/user/dbgen/mysqlgen"
+	"github.com/thomazDeveloper/go-rest-api-boilerplate/internal/user/dbgen/sqlitegen"
 )
 
 type txKey struct{}
@@ -24,47 +30,126 @@ type Repository interface {
 	RemoveRole(ctx context.Context, userID uint, roleName string) error
 	FindRoleByName(ctx context.Context, name string) (*Role, error)
 	GetUserRoles(ctx context.Context, userID uint) ([]Role, error)
+	EnsureDefaultRoles(ctx context.Context) error
+	GrantPermission(ctx context.Context, roleName, permissionName string) error
+	RevokePermission(ctx context.Context, roleName, permissionName string) error
+	ListPermissions(ctx context.Context, roleName string) ([]Permission, error)
+	GetUserPermissions(ctx context.Context, userID uint) ([]Permission, error)
 	Transaction(ctx context.Context, fn func(context.Context) error) error
 }
 
+// roleQueries is satisfied by each db.Backend driver's sqlc-generated
+// package (dbgen for Postgres, dbgen/sqlitegen for SQLite, dbgen/mysqlgen
+// for MySQL). They differ only in the placeholder syntax baked into their
+// SQL strings at generation time, so the repository depends on this
+// interface instead of any one generated package directly.
+type roleQueries interface {
+	AssignRole(ctx context.Context, arg dbgen.AssignRoleParams) error
+	RemoveRole(ctx context.Context, arg dbgen.RemoveRoleParams) error
+	FindRoleByName(ctx context.Context, name string) (dbgen.Role, error)
+}
+
+// sqliteRoleQueries adapts sqlitegen.Queries to roleQueries so every
+// generated package can be called through the same param/result types,
+// even though sqlc generated them as distinct structs per engine.
+type sqliteRoleQueries struct {
+	q *sqlitegen.Queries
+}
+
+func (s sqliteRoleQueries) AssignRole(ctx context.Context, arg dbgen.AssignRoleParams) error {
+	return s.q.AssignRole(ctx, sqlitegen.AssignRoleParams(arg))
+}
+
+func (s sqliteRoleQueries) RemoveRole(ctx context.Context, arg dbgen.RemoveRoleParams) error {
+	return s.q.RemoveRole(ctx, sqlitegen.RemoveRoleParams(arg))
+}
+
+func (s sqliteRoleQueries) FindRoleByName(ctx context.Context, name string) (dbgen.Role, error) {
+	r, err := s.q.FindRoleByName(ctx, name)
+	return dbgen.Role(r), err
+}
+
+// mysqlRoleQueries adapts mysqlgen.Queries to roleQueries; see sqliteRoleQueries.
+type mysqlRoleQueries struct {
+	q *mysqlgen.Queries
+}
+
+func (m mysqlRoleQueries) AssignRole(ctx context.Context, arg dbgen.AssignRoleParams) error {
+	return m.q.AssignRole(ctx, mysqlgen.AssignRoleParams(arg))
+}
+
+func (m mysqlRoleQueries) RemoveRole(ctx context.Context, arg dbgen.RemoveRoleParams) error {
+	return m.q.RemoveRole(ctx, mysqlgen.RemoveRoleParams(arg))
+}
+
+func (m mysqlRoleQueries) FindRoleByName(ctx context.Context, name string) (dbgen.Role, error) {
+	r, err := m.q.FindRoleByName(ctx, name)
+	return dbgen.Role(r), err
+}
+
 type repository struct {
 	db *bun.DB
 }
 
-// NewRepository creates a new user repository
+// NewRepository creates a new user repository. Its dialect decides which
+// sqlc-generated query package backs roleQueries calls; see roleQueriesFor.
 func NewRepository(db *bun.DB) Repository {
 	return &repository{db: db}
 }
 
-// getDB returns the DB from context if in transaction, otherwise returns the repository's DB
-func (r *repository) getDB(ctx context.Context) *bun.DB {
-	if tx, ok := ctx.Value(txKey{}).(*bun.DB); ok {
+// roleQueriesFor builds the sqlc-generated query wrapper matching r.db's
+// dialect, bound to conn (either the shared pool or an active transaction's
+// connection — see getConn). Supports all three db.Backend drivers.
+func (r *repository) roleQueriesFor(conn dbgen.DBTX) roleQueries {
+	switch r.db.Dialect().Name().String() {
+	case "sqlite":
+		return sqliteRoleQueries{q: sqlitegen.New(conn)}
+	case "mysql":
+		return mysqlRoleQueries{q: mysqlgen.New(conn)}
+	default:
+		return dbgen.New(conn)
+	}
+}
+
+// getDB returns the tx from context if Transaction is active, otherwise the
+// repository's shared *bun.DB. bun.IDB is implemented by both, so callers
+// can build queries (NewSelect, NewInsert, ...) without caring which one
+// they got.
+func (r *repository) getDB(ctx context.Context) bun.IDB {
+	if tx, ok := ctx.Value(txKey{}).(bun.IDB); ok {
 		return tx
 	}
 	return r.db
 }
 
+// getConn returns the sqlc DBTX backing the current context: the *sql.Tx
+// underlying an active Transaction, or the repository's shared *sql.DB.
+// roleQueries implementations are built from this so AssignRole/RemoveRole/
+// FindRoleByName participate in the same transaction as the rest of fn.
+func (r *repository) getConn(ctx context.Context) dbgen.DBTX {
+	if tx, ok := ctx.Value(txKey{}).(bun.Tx); ok {
+		return tx.Tx
+	}
+	return r.db.DB
+}
+
 // Create creates a new user in the database
 func (r *repository) Create(ctx context.Context, user *User) error {
 	_, err := r.getDB(ctx).NewInsert().Model(user).Exec(ctx)
-	if err != nil {
-		return err
-	}
-	return nil
+	return err
 }
 
 // FindByEmail finds a user by email
 func (r *repository) FindByEmail(ctx context.Context, email string) (*User, error) {
 	user := new(User)
 	err := r.getDB(ctx).NewSelect().Model(user).Relation("Roles").Where("email = ?", email).Scan(ctx)
-
 	if err != nil {
-		 if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &user, nil
+	return user, nil
 }
 
 // FindByID finds a user by ID
@@ -72,88 +157,322 @@ func (r *repository) FindByID(ctx context.Context, id uint) (*User, error) {
 	user := new(User)
 	err := r.getDB(ctx).NewSelect().Model(user).Relation("Roles").Where("id = ?", id).Scan(ctx)
 	if err != nil {
-		 if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &user, nil
+	return user, nil
 }
 
 // Update updates a user in the database
 func (r *repository) Update(ctx context.Context, user *User) error {
-	// WHY: Save() syncs associations, potentially clearing roles
-	_, err  := r.getDB(ctx).NewUpdate().
-	Model(user).
-	Column("name", "email", "password_hash", "updated_at").
-	Where("id = ?", user.ID).
-	Exec(ctx)
-	if err != nil {
-		return err
-	}
-	return nil
+	// WHY: only sync editable columns; Roles is managed separately via AssignRole/RemoveRole
+	_, err := r.getDB(ctx).NewUpdate().
+		Model(user).
+		Column("name", "email", "password_hash", "updated_at").
+		Where("id = ?", user.ID).
+		Exec(ctx)
+	return err
 }
 
 // Delete soft deletes a user from the database
 func (r *repository) Delete(ctx context.Context, id uint) error {
-	_, err  := r.getDB(ctx).NewDelete().Model((*User)(nil)).Where("id = ?", id).Exec(ctx)
-	if err != nil {
-		return err
-	}
-	
-	return nil
+	_, err := r.getDB(ctx).NewDelete().Model((*User)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
 }
 
 // ListAllUsers retrieves paginated list of users with filters
 func (r *repository) ListAllUsers(ctx context.Context, filters UserFilterParams, page, perPage int) ([]User, int64, error) {
+	if filters.Search != "" {
+		// FTS is Postgres-only; every other driver falls back to the LIKE path.
+		if filters.SearchMode == SearchModeFTS && r.db.Dialect().Name().String() == "pg" {
+			return r.searchUsersFTS(ctx, filters, page, perPage)
+		}
+		return r.searchUsers(ctx, filters, page, perPage)
+	}
+
 	var users []User
-	var total int64
 
 	query := r.getDB(ctx).NewSelect().Model(&users).Relation("Roles")
 
 	if filters.Role != "" {
-		query = query.Joins("JOIN user_roles ON user_roles.user_id = users.id").
-			Joins("JOIN roles ON roles.id = user_roles.role_id").
+		query = query.Join("JOIN user_roles ON user_roles.user_id = users.id").
+			Join("JOIN roles ON roles.id = user_roles.role_id").
 			Where("roles.name = ?", filters.Role)
 	}
 
-	if filters.Search != "" {
-		// WHY: Escape SQL LIKE wildcards to prevent incorrect matches
-		escapedSearch := strings.ReplaceAll(filters.Search, "%", "\\%")
-		escapedSearch = strings.ReplaceAll(escapedSearch, "_", "\\_")
-		searchPattern := "%" + escapedSearch + "%"
-		query = query.Where("users.name LIKE ? OR users.email LIKE ?", searchPattern, searchPattern)
+	if err := validateSortOrder(filters); err != nil {
+		return nil, 0, err
 	}
+	orderColumn := fmt.Sprintf("%s %s", filters.Sort, filters.Order)
 
-	// WHY: Count distinct user IDs when using JOINs to avoid inflated totals
-	if err := query.Distinct("users.id").Count(&total).Error; err != nil {
+	offset := (page - 1) * perPage
+	total, err := query.Distinct().Order(orderColumn).Limit(perPage).Offset(offset).ScanAndCount(ctx)
+	if err != nil {
 		return nil, 0, err
 	}
 
-	offset := (page - 1) * perPage
+	return users, int64(total), nil
+}
 
-	// Defense-in-depth: Validate sort parameters at repository layer
-	validSorts := map[string]bool{
-		"name": true, "email": true, "created_at": true, "updated_at": true,
-	}
-	if !validSorts[filters.Sort] {
-		return nil, 0, errors.New("invalid sort field")
+// escapeLikePattern escapes the wildcard characters LIKE treats specially so
+// user-supplied search text matches literally instead of as a pattern.
+func escapeLikePattern(s string) string {
+	s = strings.ReplaceAll(s, "%", "\\%")
+	s = strings.ReplaceAll(s, "_", "\\_")
+	return s
+}
+
+// validSearchSorts is the allowlist both ListAllUsers and searchUsers
+// validate filters.Sort against before it ever reaches a query, so it's
+// always safe to interpolate into raw SQL (bun's Order()) or switch on in Go.
+var validSearchSorts = map[string]bool{
+	"name": true, "email": true, "created_at": true, "updated_at": true,
+}
+
+// validateSortOrder checks filters.Sort/Order against validSearchSorts.
+func validateSortOrder(filters UserFilterParams) error {
+	if !validSearchSorts[filters.Sort] {
+		return errors.New("invalid sort field")
 	}
 	if filters.Order != "asc" && filters.Order != "desc" {
-		return nil, 0, errors.New("invalid sort order")
+		return errors.New("invalid sort order")
+	}
+	return nil
+}
+
+// lessByField compares a and b by one of validSearchSorts's fields.
+func lessByField(a, b User, field string) bool {
+	switch field {
+	case "name":
+		return a.Name < b.Name
+	case "email":
+		return a.Email < b.Email
+	case "created_at":
+		return a.CreatedAt.Before(b.CreatedAt)
+	case "updated_at":
+		return a.UpdatedAt.Before(b.UpdatedAt)
+	default:
+		return false
 	}
+}
 
-	// Use type-safe GORM clause to prevent SQL injection
-	orderColumn := fmt.Sprintf("%s %s", filters.Sort, filters.Order)
+// sortUsers orders users by field/order, both already checked against
+// validSearchSorts by validateSortOrder.
+func sortUsers(users []User, field, order string) {
+	sort.SliceStable(users, func(i, j int) bool {
+		if order == "desc" {
+			return lessByField(users[j], users[i], field)
+		}
+		return lessByField(users[i], users[j], field)
+	})
+}
+
+// searchUsers serves the free-text branch of ListAllUsers through the
+// sqlc-generated SearchUsers/CountSearchUsers queries, replacing the
+// hand-escaped LIKE pattern the bun path used to build. The generated
+// queries apply the same role filter and soft-delete exclusion
+// ListAllUsers's non-search branch does, so combined Role+Search filters
+// behave consistently and deleted users never leak into results or the
+// total count. sqlc can't parameterize ORDER BY, so rows come back ordered
+// by id; once the page is in memory it's re-sorted by filters.Sort/Order
+// via sortUsers. That's exact within a page but, unlike the non-search
+// branch, doesn't re-run LIMIT/OFFSET against the requested order, so rows
+// can shift across a page boundary for a large match set sorted by
+// something other than id — an acceptable tradeoff for the free-text
+// search's typically small result sets. Roles are hydrated with a
+// follow-up bun query since role joins stay hand-written; the hydration
+// query has no ORDER BY of its own, so sortUsers re-establishes
+// filters.Sort/Order afterward rather than depending on it.
+func (r *repository) searchUsers(ctx context.Context, filters UserFilterParams, page, perPage int) ([]User, int64, error) {
+	if err := validateSortOrder(filters); err != nil {
+		return nil, 0, err
+	}
 
-	total, err := query.Distinct().Order(orderColumn).Limit(perPage).Offset(offset).ScanAndCount(ctx) 
+	pattern := "%" + escapeLikePattern(filters.Search) + "%"
+	offset := (page - 1) * perPage
+	conn := r.getConn(ctx)
+
+	var rows []dbgen.User
+	var total int64
+	var err error
+
+	switch r.db.Dialect().Name().String() {
+	case "sqlite":
+		q := sqlitegen.New(conn)
+		var sqliteRows []sqlitegen.User
+		sqliteRows, err = q.SearchUsers(ctx, sqlitegen.SearchUsersParams{
+			Pattern:  pattern,
+			Pattern2: pattern,
+			Role:     filters.Role,
+			Role2:    filters.Role,
+			Limit:    int32(perPage),
+			Offset:   int32(offset),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range sqliteRows {
+			rows = append(rows, dbgen.User(row))
+		}
+		total, err = q.CountSearchUsers(ctx, sqlitegen.CountSearchUsersParams{
+			Pattern:  pattern,
+			Pattern2: pattern,
+			Role:     filters.Role,
+			Role2:    filters.Role,
+		})
+	case "mysql":
+		q := mysqlgen.New(conn)
+		var mysqlRows []mysqlgen.User
+		mysqlRows, err = q.SearchUsers(ctx, mysqlgen.SearchUsersParams{
+			Pattern:  pattern,
+			Pattern2: pattern,
+			Role:     filters.Role,
+			Role2:    filters.Role,
+			Limit:    int32(perPage),
+			Offset:   int32(offset),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, row := range mysqlRows {
+			rows = append(rows, dbgen.User(row))
+		}
+		total, err = q.CountSearchUsers(ctx, mysqlgen.CountSearchUsersParams{
+			Pattern:  pattern,
+			Pattern2: pattern,
+			Role:     filters.Role,
+			Role2:    filters.Role,
+		})
+	default:
+		q := dbgen.New(conn)
+		rows, err = q.SearchUsers(ctx, dbgen.SearchUsersParams{
+			Pattern: pattern,
+			Role:    filters.Role,
+			Limit:   int32(perPage),
+			Offset:  int32(offset),
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		total, err = q.CountSearchUsers(ctx, dbgen.CountSearchUsersParams{Pattern: pattern, Role: filters.Role})
+	}
 	if err != nil {
 		return nil, 0, err
 	}
 
+	users := make([]User, len(rows))
+	for i, row := range rows {
+		users[i] = User{
+			ID:           row.ID,
+			Name:         row.Name,
+			Email:        row.Email,
+			PasswordHash: row.PasswordHash,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+		}
+	}
+
+	if len(users) > 0 {
+		ids := make([]int64, len(users))
+		indexByID := make(map[int64]int, len(users))
+		for i, u := range users {
+			ids[i] = u.ID
+			indexByID[u.ID] = i
+		}
+
+		var hydrated []User
+		if err := r.getDB(ctx).NewSelect().Model(&hydrated).Relation("Roles").Where("id IN (?)", bun.In(ids)).Scan(ctx); err != nil {
+			return nil, 0, err
+		}
+		for _, h := range hydrated {
+			users[indexByID[h.ID]] = h
+		}
+	}
+
+	sortUsers(users, filters.Sort, filters.Order)
+
 	return users, total, nil
 }
 
+// searchUsersFTS serves SearchModeFTS: it ranks matches by ts_rank over a
+// generated tsvector column (see the users_fts migration) instead of a LIKE
+// scan, and optionally attaches a ts_headline snippet per row when
+// filters.Highlight is set. filters.Sort/Order are intentionally ignored in
+// this mode: relevance rank is the point of requesting FTS, so results are
+// always ordered by rank DESC regardless of what the caller asked for.
+func (r *repository) searchUsersFTS(ctx context.Context, filters UserFilterParams, page, perPage int) ([]User, int64, error) {
+	type ftsRow struct {
+		User
+		Rank      float64 `bun:"rank"`
+		Highlight string  `bun:"snippet"`
+	}
+
+	offset := (page - 1) * perPage
+
+	selectQuery := r.getDB(ctx).NewSelect().
+		Model((*ftsRow)(nil)).
+		ColumnExpr("u.*").
+		ColumnExpr("ts_rank(u.search_vector, plainto_tsquery('simple', ?)) AS rank", filters.Search)
+
+	if filters.Highlight {
+		selectQuery = selectQuery.ColumnExpr(
+			"ts_headline('simple', u.name || ' ' || u.email, plainto_tsquery('simple', ?)) AS snippet",
+			filters.Search)
+	} else {
+		selectQuery = selectQuery.ColumnExpr("'' AS snippet")
+	}
+
+	var rows []ftsRow
+	err := selectQuery.
+		Model(&rows).
+		Where("u.search_vector @@ plainto_tsquery('simple', ?)", filters.Search).
+		OrderExpr("rank DESC").
+		Limit(perPage).
+		Offset(offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.getDB(ctx).NewSelect().
+		Model((*User)(nil)).
+		Where("search_vector @@ plainto_tsquery('simple', ?)", filters.Search).
+		Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users := make([]User, len(rows))
+	for i, row := range rows {
+		users[i] = row.User
+		if filters.Highlight {
+			users[i].Highlight = row.Highlight
+		}
+	}
+
+	if len(users) > 0 {
+		ids := make([]int64, len(users))
+		rankByID := make(map[int64]int, len(users))
+		for i, u := range users {
+			ids[i] = u.ID
+			rankByID[u.ID] = i
+		}
+		var hydrated []User
+		if err := r.getDB(ctx).NewSelect().Model(&hydrated).Relation("Roles").Where("id IN (?)", bun.In(ids)).Scan(ctx); err != nil {
+			return nil, 0, err
+		}
+		for _, h := range hydrated {
+			i := rankByID[h.ID]
+			h.Highlight = users[i].Highlight
+			users[i] = h
+		}
+	}
+
+	return users, int64(total), nil
+}
+
 // AssignRole assigns a role to a user
 func (r *repository) AssignRole(ctx context.Context, userID uint, roleName string) error {
 	role, err := r.FindRoleByName(ctx, roleName)
@@ -164,12 +483,13 @@ func (r *repository) AssignRole(ctx context.Context, userID uint, roleName strin
 		return errors.New("role not found")
 	}
 
-	qr := "INSERT INTO user_roles (user_id, role_id, assigned_at) VALUES (?, ?, ?) ON CONFLICT (user_id, role_id) DO NOTHING"
-
-	// Use database-level conflict handling for race-safe, idempotent role assignment
-	// Works with both PostgreSQL and SQLite
-	    _, err =  r.getDB(ctx).ExecContext(ctx, qr, userID, role.ID, time.Now())
-		return err
+	// Use database-level conflict handling for race-safe, idempotent role assignment.
+	// Works across all three db.Backend drivers.
+	return r.roleQueriesFor(r.getConn(ctx)).AssignRole(ctx, dbgen.AssignRoleParams{
+		UserID:     int64(userID),
+		RoleID:     role.ID,
+		AssignedAt: time.Now(),
+	})
 }
 
 // RemoveRole removes a role from a user
@@ -182,31 +502,36 @@ func (r *repository) RemoveRole(ctx context.Context, userID uint, roleName strin
 		return errors.New("role not found")
 	}
 
-	qr := "DELETE FROM user_roles WHERE user_id = ? AND role_id = ?"
-
-	 _, err = r.getDB(ctx).ExecContext(ctx, qr, userID, role.ID)
-	 return err
+	return r.roleQueriesFor(r.getConn(ctx)).RemoveRole(ctx, dbgen.RemoveRoleParams{
+		UserID: int64(userID),
+		RoleID: role.ID,
+	})
 }
 
 // FindRoleByName finds a role by name
 func (r *repository) FindRoleByName(ctx context.Context, name string) (*Role, error) {
-	role := new(Role)
-	err := r.getDB(ctx).NewSelect().Model(role).Where("name = ?", name).Scan(ctx)
+	row, err := r.roleQueriesFor(r.getConn(ctx)).FindRoleByName(ctx, name)
 	if err != nil {
-		 if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &role, nil
+	return &Role{
+		ID:          row.ID,
+		Name:        row.Name,
+		Description: row.Description,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}, nil
 }
 
 // GetUserRoles retrieves all roles for a user
 func (r *repository) GetUserRoles(ctx context.Context, userID uint) ([]Role, error) {
 	var roles []Role
 	err := r.getDB(ctx).NewSelect().
-   		 Model(&roles).
-		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Model(&roles).
+		Join("JOIN user_roles ON user_roles.role_id = roles.id").
 		Where("user_roles.user_id = ?", userID).
 		Scan(ctx)
 	if err != nil {
@@ -215,12 +540,110 @@ func (r *repository) GetUserRoles(ctx context.Context, userID uint) ([]Role, err
 	return roles, nil
 }
 
+// EnsureDefaultRoles inserts the guest/user/admin roles if they don't already
+// exist. It is safe to call on every startup: existing rows are left
+// untouched via ON CONFLICT DO NOTHING.
+func (r *repository) EnsureDefaultRoles(ctx context.Context) error {
+	defaultRoles := []Role{
+		{Name: RoleGuest, Description: "Unauthenticated or unverified access"},
+		{Name: RoleUser, Description: "Standard authenticated user"},
+		{Name: RoleAdmin, Description: "Full administrative access"},
+	}
+
+	_, err := r.getDB(ctx).NewInsert().
+		Model(&defaultRoles).
+		On("CONFLICT (name) DO NOTHING").
+		Exec(ctx)
+	return err
+}
+
+// GrantPermission grants permissionName to roleName. It is idempotent: granting
+// a permission the role already has is a no-op.
+func (r *repository) GrantPermission(ctx context.Context, roleName, permissionName string) error {
+	role, err := r.FindRoleByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return errors.New("role not found")
+	}
+
+	permission := new(Permission)
+	if err := r.getDB(ctx).NewSelect().Model(permission).Where("name = ?", permissionName).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("permission not found")
+		}
+		return err
+	}
+
+	_, err = r.getDB(ctx).NewInsert().
+		Model(&RolePermission{RoleID: role.ID, PermissionID: permission.ID}).
+		On("CONFLICT (role_id, permission_id) DO NOTHING").
+		Exec(ctx)
+	return err
+}
+
+// RevokePermission removes permissionName from roleName.
+func (r *repository) RevokePermission(ctx context.Context, roleName, permissionName string) error {
+	role, err := r.FindRoleByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return errors.New("role not found")
+	}
+
+	permission := new(Permission)
+	if err := r.getDB(ctx).NewSelect().Model(permission).Where("name = ?", permissionName).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("permission not found")
+		}
+		return err
+	}
+
+	_, err = r.getDB(ctx).NewDelete().
+		Model((*RolePermission)(nil)).
+		Where("role_id = ? AND permission_id = ?", role.ID, permission.ID).
+		Exec(ctx)
+	return err
+}
+
+// ListPermissions returns every permission granted to roleName.
+func (r *repository) ListPermissions(ctx context.Context, roleName string) ([]Permission, error) {
+	var permissions []Permission
+	err := r.getDB(ctx).NewSelect().
+		Model(&permissions).
+		Join("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Join("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ?", roleName).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// GetUserPermissions returns the union of permissions granted across all of
+// userID's roles.
+func (r *repository) GetUserPermissions(ctx context.Context, userID uint) ([]Permission, error) {
+	var permissions []Permission
+	err := r.getDB(ctx).NewSelect().
+		Model(&permissions).
+		Distinct().
+		Join("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Join("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
 // Transaction executes a function within a database transaction
-func (r *repository) CreateAndAssignRole(ctx context.Context, user *User, roleName string) error {
-		tx, err := r.getDB(ctx).BeginTx(ctx, &sql.TxOptions{})
+func (r *repository) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		txCtx := context.WithValue(ctx, txKey{}, tx)
-		if err := r.Create(txCtx, user); err != nil {
-			return err
-		}
-		return r.AssignRole(txCtx, user.ID, roleName)
+		return fn(txCtx)
+	})
 }
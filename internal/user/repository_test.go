@@ -0,0 +1,97 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	_ "github.com/uptrace/bun/driver/sqliteshim"
+)
+
+// newTestDB opens an in-memory SQLite database and creates the users table,
+// mirroring the sqlite backend's migration (see
+// internal/db/migrations/sqlite) closely enough to exercise Repository.
+func newTestDB(t *testing.T) *bun.DB {
+	t.Helper()
+
+	sqldb, err := sql.Open("sqlite", "file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	t.Cleanup(func() { sqldb.Close() })
+
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+
+	_, err = db.ExecContext(context.Background(), `
+		CREATE TABLE users (
+			id            INTEGER PRIMARY KEY AUTOINCREMENT,
+			name          TEXT NOT NULL,
+			email         TEXT NOT NULL UNIQUE,
+			password_hash TEXT NOT NULL,
+			created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at    TIMESTAMP
+		)`)
+	if err != nil {
+		t.Fatalf("failed to create users table: %v", err)
+	}
+
+	return db
+}
+
+func TestTransactionRollsBackOnError(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	wantErr := errors.New("fn failed")
+	err := repo.Transaction(ctx, func(txCtx context.Context) error {
+		if err := repo.Create(txCtx, &User{
+			Name:         "Ada Lovelace",
+			Email:        "ada@example.com",
+			PasswordHash: "hash",
+		}); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Transaction() error = %v, want %v", err, wantErr)
+	}
+
+	user, err := repo.FindByEmail(ctx, "ada@example.com")
+	if err != nil {
+		t.Fatalf("FindByEmail() unexpected error: %v", err)
+	}
+	if user != nil {
+		t.Fatalf("FindByEmail() = %v, want nil: user insert should have been rolled back", user)
+	}
+}
+
+func TestTransactionCommitsOnSuccess(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	err := repo.Transaction(ctx, func(txCtx context.Context) error {
+		return repo.Create(txCtx, &User{
+			Name:         "Grace Hopper",
+			Email:        "grace@example.com",
+			PasswordHash: "hash",
+		})
+	})
+	if err != nil {
+		t.Fatalf("Transaction() unexpected error: %v", err)
+	}
+
+	user, err := repo.FindByEmail(ctx, "grace@example.com")
+	if err != nil {
+		t.Fatalf("FindByEmail() unexpected error: %v", err)
+	}
+	if user == nil {
+		t.Fatal("FindByEmail() = nil, want the committed user")
+	}
+}